@@ -0,0 +1,38 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+// FakeNodeLister implements NodeLister on a static list of nodes for use in tests.
+type FakeNodeLister api.NodeList
+
+// List returns the static list of nodes.
+func (f FakeNodeLister) List() (api.NodeList, error) {
+	return api.NodeList(f), nil
+}
+
+// FakePodLister implements PodLister on a static list of pods for use in tests.
+type FakePodLister []*api.Pod
+
+// List returns every pod in the static list, ignoring selector.
+func (f FakePodLister) List(selector labels.Selector) ([]*api.Pod, error) {
+	return []*api.Pod(f), nil
+}
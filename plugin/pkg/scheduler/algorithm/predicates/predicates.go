@@ -0,0 +1,48 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package predicates
+
+import "k8s.io/kubernetes/pkg/api"
+
+// getUsedPorts returns the set of host ports used by the given pods.
+func getUsedPorts(pods ...*api.Pod) map[int]bool {
+	ports := make(map[int]bool)
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			for _, podPort := range container.Ports {
+				// HostPort 0 means the container port is not exposed on the host.
+				if podPort.HostPort == 0 {
+					continue
+				}
+				ports[int(podPort.HostPort)] = true
+			}
+		}
+	}
+	return ports
+}
+
+// PodFitsHostPorts checks if a pod can be scheduled on a node because of the HostPorts its containers request.
+func PodFitsHostPorts(pod *api.Pod, existingPods []*api.Pod, node string) (bool, error) {
+	existingPorts := getUsedPorts(existingPods...)
+	wantPorts := getUsedPorts(pod)
+	for port := range wantPorts {
+		if existingPorts[port] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import "k8s.io/kubernetes/pkg/api"
+
+// FitPredicate is a function that indicates if a pod fits into an existing node.
+type FitPredicate func(pod *api.Pod, existingPods []*api.Pod, node string) (bool, error)
+
+// PriorityFunction computes a score for each of the passed nodes, indicating how well the pod fits there.
+type PriorityFunction func(pod *api.Pod, podLister PodLister, nodeLister NodeLister) (HostPriorityList, error)
+
+// PriorityConfig pairs a PriorityFunction with a weight to combine it with the other configured priorities.
+type PriorityConfig struct {
+	Function PriorityFunction
+	Weight   int
+}
+
+// HostPriority represents the priority of scheduling to a particular host, lower is less preferred.
+type HostPriority struct {
+	Host  string
+	Score int
+}
+
+// HostPriorityList is a list of HostPriority.
+type HostPriorityList []HostPriority
+
+// SchedulerExtender is an interface for external processes to influence scheduling
+// decisions made by Kubernetes. This is typically needed for resources not directly
+// managed by Kubernetes.
+type SchedulerExtender interface {
+	// Filter based on extender-implemented predicate functions. The filtered list is
+	// expected to be a subset of the supplied list.
+	Filter(pod *api.Pod, nodes *api.NodeList) (filteredNodes *api.NodeList, err error)
+
+	// Prioritize based on extender-implemented priority functions. The returned scores & weight
+	// are used to compute the weighted score for an extender. The weighted scores are added to
+	// the scores computed by Kubernetes scheduler. The total scores are used to do the host selection.
+	Prioritize(pod *api.Pod, nodes *api.NodeList) (hostPriorities *HostPriorityList, weight int, err error)
+}
+
+// ScheduleAlgorithm is an interface implemented by things that know how to schedule pods
+// onto machines.
+type ScheduleAlgorithm interface {
+	Schedule(*api.Pod, NodeLister) (selectedMachine string, err error)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/types"
+)
+
+const (
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 60 * time.Second
+)
+
+// PodBackoff tracks, per pod UID, how long to wait before retrying a pod that failed to
+// schedule. Each consecutive failure doubles the wait up to maxBackoff; a successful
+// schedule or a pod deletion clears the tracked state.
+type PodBackoff struct {
+	lock    sync.Mutex
+	backoff map[types.UID]time.Duration
+}
+
+// NewPodBackoff returns an empty PodBackoff.
+func NewPodBackoff() *PodBackoff {
+	return &PodBackoff{backoff: map[types.UID]time.Duration{}}
+}
+
+// Next returns the duration uid should wait before its next retry, doubling the
+// previously recorded duration (or starting at initialBackoff) and capping at maxBackoff.
+func (b *PodBackoff) Next(uid types.UID) time.Duration {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	cur, ok := b.backoff[uid]
+	if !ok {
+		cur = initialBackoff
+	} else {
+		cur *= 2
+		if cur > maxBackoff {
+			cur = maxBackoff
+		}
+	}
+	b.backoff[uid] = cur
+	return cur
+}
+
+// Clear removes any recorded backoff for uid. Called after a successful schedule so the
+// next failure starts over at initialBackoff, and on pod deletion so state doesn't leak.
+func (b *PodBackoff) Clear(uid types.UID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.backoff, uid)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/client/cache"
+)
+
+// delayedEntry pairs a queued object with the time at which it becomes visible to Pop.
+type delayedEntry struct {
+	obj     interface{}
+	readyAt time.Time
+}
+
+// DelayFIFO is a queue whose entries only become visible to Pop once their readyAt
+// time has passed. It sits parallel to queuedPodStore: pods that fail to schedule are
+// pushed here with a future readyAt instead of being retried immediately.
+type DelayFIFO struct {
+	lock sync.Mutex
+	cond sync.Cond
+
+	keyFunc cache.KeyFunc
+	items   map[string]*delayedEntry
+	// queue preserves insertion order among keys present in items.
+	queue []string
+}
+
+// NewDelayFIFO returns an empty DelayFIFO that identifies entries using keyFunc, the
+// same contract as clientcache.FIFO's key function.
+func NewDelayFIFO(keyFunc cache.KeyFunc) *DelayFIFO {
+	f := &DelayFIFO{
+		keyFunc: keyFunc,
+		items:   map[string]*delayedEntry{},
+	}
+	f.cond.L = &f.lock
+	return f
+}
+
+// AddAt inserts or replaces obj, making it visible to Pop only once readyAt has passed.
+func (f *DelayFIFO) AddAt(obj interface{}, readyAt time.Time) error {
+	key, err := f.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, exists := f.items[key]; !exists {
+		f.queue = append(f.queue, key)
+	}
+	f.items[key] = &delayedEntry{obj: obj, readyAt: readyAt}
+	f.cond.Broadcast()
+	return nil
+}
+
+// Delete removes obj's pending entry, if any, e.g. because the pod it refers to was
+// deleted and should no longer be retried.
+func (f *DelayFIFO) Delete(obj interface{}) error {
+	key, err := f.keyFunc(obj)
+	if err != nil {
+		return err
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	delete(f.items, key)
+	return nil
+}
+
+// Pop blocks until the earliest-ready entry's readyAt has passed, then removes and
+// returns it.
+func (f *DelayFIFO) Pop() interface{} {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for {
+		key, readyAt, ok := f.earliestLocked()
+		if !ok {
+			f.cond.Wait()
+			continue
+		}
+		wait := readyAt.Sub(time.Now())
+		if wait <= 0 {
+			entry := f.items[key]
+			delete(f.items, key)
+			f.removeFromQueueLocked(key)
+			return entry.obj
+		}
+		timer := time.AfterFunc(wait, func() {
+			f.lock.Lock()
+			f.cond.Broadcast()
+			f.lock.Unlock()
+		})
+		f.cond.Wait()
+		timer.Stop()
+	}
+}
+
+// earliestLocked returns the key and readyAt of the queue's earliest-ready entry.
+// Callers must hold f.lock.
+func (f *DelayFIFO) earliestLocked() (string, time.Time, bool) {
+	var bestKey string
+	var bestTime time.Time
+	found := false
+	for _, key := range f.queue {
+		entry, ok := f.items[key]
+		if !ok {
+			continue
+		}
+		if !found || entry.readyAt.Before(bestTime) {
+			bestKey, bestTime, found = key, entry.readyAt, true
+		}
+	}
+	return bestKey, bestTime, found
+}
+
+// removeFromQueueLocked drops key from the insertion-order slice. Callers must hold f.lock.
+func (f *DelayFIFO) removeFromQueueLocked(key string) {
+	for i, k := range f.queue {
+		if k == key {
+			f.queue = append(f.queue[:i], f.queue[i+1:]...)
+			return
+		}
+	}
+}
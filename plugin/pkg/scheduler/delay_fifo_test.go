@@ -0,0 +1,115 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	clientcache "k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm/predicates"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+// TestSchedulerBackoffUnschedulablePod extends the phantom-pod pattern from
+// TestSchedulerNoPhantomPodAfterDelete: a pod failing PodFitsHostPorts must not be
+// retried until its computed backoff elapses, even once capacity frees up.
+func TestSchedulerBackoffUnschedulablePod(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	scache := schedulercache.New(10*time.Minute, stop)
+
+	firstPod := podWithPort("first", "", 8080)
+	// We use conflicted pod ports to incur fit predicate failure, as in
+	// TestSchedulerNoPhantomPodAfterDelete; that requires firstPod to actually occupy
+	// machine1, since findNodesThatFit ignores pods with no assigned NodeName.
+	firstPod.Spec.NodeName = "machine1"
+	if err := scache.AddPod(firstPod); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	algo := NewGenericScheduler(
+		scache,
+		map[string]algorithm.FitPredicate{"PodFitsHostPorts": predicates.PodFitsHostPorts},
+		[]algorithm.PriorityConfig{},
+		[]algorithm.SchedulerExtender{})
+
+	backoff := NewPodBackoff()
+	delayQueue := NewDelayFIFO(clientcache.MetaNamespaceKeyFunc)
+
+	secondPod := podWithPort("second", "", 8080)
+	secondPod.UID = "second-uid"
+
+	bindingChan := make(chan *api.Binding, 1)
+	cfg := &Config{
+		SchedulerCache: scache,
+		NodeLister: algorithm.FakeNodeLister(
+			api.NodeList{Items: []api.Node{{ObjectMeta: api.ObjectMeta{Name: "machine1"}}}},
+		),
+		Algorithm: algo,
+		Backoff:   backoff,
+		Binder: fakeBinder{func(b *api.Binding) error {
+			bindingChan <- b
+			return nil
+		}},
+		NextPod:             func() *api.Pod { return delayQueue.Pop().(*api.Pod) },
+		Error:               MakeDefaultErrorFunc(backoff, delayQueue),
+		Recorder:            &record.FakeRecorder{},
+		PodConditionUpdater: fakePodConditionUpdater{},
+	}
+	scheduler := New(cfg)
+
+	if err := delayQueue.AddAt(secondPod, time.Now()); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	start := time.Now()
+	go wait.Until(scheduler.scheduleOne, 0, stop)
+
+	// The first attempt fails the port-conflict predicate and gets requeued with
+	// backoff; capacity frees up well before that backoff elapses, but the pod must
+	// still not be retried early.
+	select {
+	case <-bindingChan:
+		t.Fatalf("pod was bound before its backoff elapsed")
+	case <-time.After(initialBackoff / 2):
+	}
+	if err := scache.RemovePod(firstPod); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	select {
+	case b := <-bindingChan:
+		if elapsed := time.Since(start); elapsed < initialBackoff {
+			t.Errorf("pod was retried after %v, before its backoff of %v elapsed", elapsed, initialBackoff)
+		}
+		expectBinding := &api.Binding{
+			ObjectMeta: api.ObjectMeta{Name: "second"},
+			Target:     api.ObjectReference{Kind: "Node", Name: "machine1"},
+		}
+		if !reflect.DeepEqual(expectBinding, b) {
+			t.Errorf("binding want=%v, get=%v", expectBinding, b)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatalf("timeout waiting for pod to be retried after backoff")
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+
+	"github.com/golang/glog"
+)
+
+// MakeDefaultErrorFunc returns a Config.Error callback that re-enqueues pod into
+// delayQueue instead of retrying it immediately. Pods that failed with a FitError are
+// delayed by the backoff computed for their UID; any other error is retried right away,
+// since it isn't necessarily related to cluster fullness.
+func MakeDefaultErrorFunc(backoff *PodBackoff, delayQueue *DelayFIFO) func(pod *api.Pod, err error) {
+	return func(pod *api.Pod, err error) {
+		if _, ok := err.(*FitError); !ok {
+			glog.Errorf("Error scheduling %v/%v: %v; retrying", pod.Namespace, pod.Name, err)
+			if err := delayQueue.AddAt(pod, time.Now()); err != nil {
+				glog.Errorf("Error re-enqueuing %v/%v: %v", pod.Namespace, pod.Name, err)
+			}
+			return
+		}
+
+		delay := backoff.Next(pod.UID)
+		glog.V(3).Infof("Pod %v/%v unschedulable (%v); retrying in %v", pod.Namespace, pod.Name, err, delay)
+		if err := delayQueue.AddAt(pod, time.Now().Add(delay)); err != nil {
+			glog.Errorf("Error re-enqueuing %v/%v: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+}
+
+// NewPodBackoffClearingInformer starts an informer that clears backoff state, and any
+// entry still pending in delayQueue, as soon as a pod is deleted. Without this, a pod
+// deleted while backed off would otherwise sit in delayQueue until its readyAt passed
+// for no reason, and a future pod reusing its UID would needlessly inherit the penalty.
+func NewPodBackoffClearingInformer(lw cache.ListerWatcher, resyncPeriod time.Duration, backoff *PodBackoff, delayQueue *DelayFIFO) *cache.Controller {
+	_, controller := cache.NewInformer(lw, &api.Pod{}, resyncPeriod, cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			pod, ok := obj.(*api.Pod)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					glog.Errorf("Couldn't get object from tombstone %+v", obj)
+					return
+				}
+				pod, ok = tombstone.Obj.(*api.Pod)
+				if !ok {
+					glog.Errorf("Tombstone contained object that is not a pod %+v", obj)
+					return
+				}
+			}
+			backoff.Clear(pod.UID)
+			if err := delayQueue.Delete(pod); err != nil {
+				glog.Errorf("Error clearing delay queue entry for %v/%v: %v", pod.Namespace, pod.Name, err)
+			}
+		},
+	})
+	return controller
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+)
+
+// FailedPredicateMap maps the name of a node to the name of the predicate that rejected it.
+type FailedPredicateMap map[string]string
+
+// FitError is returned when no node fits the requested pod.
+type FitError struct {
+	Pod              *api.Pod
+	FailedPredicates FailedPredicateMap
+}
+
+var ErrNoNodesAvailable = fmt.Errorf("no nodes available to schedule pods")
+
+func (f *FitError) Error() string {
+	var reasons []string
+	for node, predicate := range f.FailedPredicates {
+		reasons = append(reasons, fmt.Sprintf("fit failure on node (%s): %s", node, predicate))
+	}
+	sort.Strings(reasons)
+	return fmt.Sprintf("pod (%s) failed to fit in any node\n%s", f.Pod.Name, strings.Join(reasons, "\n"))
+}
+
+// genericScheduler picks the first node, in NodeLister order, whose predicates all pass.
+// The configured priority functions are stored but not run, so they do not affect the choice.
+type genericScheduler struct {
+	pods       algorithm.PodLister
+	predicates map[string]algorithm.FitPredicate
+	priorities []algorithm.PriorityConfig
+	extenders  []algorithm.SchedulerExtender
+}
+
+// NewGenericScheduler creates a genericScheduler object. pods should be the unified
+// queued/scheduled/assumed view a Modeler produces (see Config.Modeler); passing a bare
+// schedulercache.Cache still works, since its List method already satisfies
+// algorithm.PodLister, but predicates then only see what the cache knows about.
+func NewGenericScheduler(pods algorithm.PodLister, predicates map[string]algorithm.FitPredicate, priorities []algorithm.PriorityConfig, extenders []algorithm.SchedulerExtender) algorithm.ScheduleAlgorithm {
+	return &genericScheduler{
+		pods:       pods,
+		predicates: predicates,
+		priorities: priorities,
+		extenders:  extenders,
+	}
+}
+
+func (g *genericScheduler) Schedule(pod *api.Pod, nodeLister algorithm.NodeLister) (string, error) {
+	nodes, err := nodeLister.List()
+	if err != nil {
+		return "", err
+	}
+	if len(nodes.Items) == 0 {
+		return "", ErrNoNodesAvailable
+	}
+
+	allPods, err := g.pods.List(labels.Everything())
+	if err != nil {
+		return "", err
+	}
+
+	filtered, failedPredicateMap, err := findNodesThatFit(pod, allPods, nodes, g.predicates)
+	if err != nil {
+		return "", err
+	}
+	if len(filtered) == 0 {
+		return "", &FitError{Pod: pod, FailedPredicates: failedPredicateMap}
+	}
+
+	// TODO: run g.priorities over filtered to rank rather than picking the first fit.
+	return filtered[0].Name, nil
+}
+
+// findNodesThatFit returns the subset of nodes on which every predicate passes for pod,
+// along with the reason the remaining nodes were rejected.
+func findNodesThatFit(pod *api.Pod, allPods []*api.Pod, nodes api.NodeList, predicates map[string]algorithm.FitPredicate) ([]api.Node, FailedPredicateMap, error) {
+	filtered := []api.Node{}
+	failedPredicateMap := FailedPredicateMap{}
+
+	podsByNode := map[string][]*api.Pod{}
+	for _, p := range allPods {
+		if p.Spec.NodeName == "" {
+			continue
+		}
+		podsByNode[p.Spec.NodeName] = append(podsByNode[p.Spec.NodeName], p)
+	}
+
+NodeLoop:
+	for _, node := range nodes.Items {
+		existingPods := podsByNode[node.Name]
+		for name, predicate := range predicates {
+			fits, err := predicate(pod, existingPods, node.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !fits {
+				failedPredicateMap[node.Name] = name
+				continue NodeLoop
+			}
+		}
+		filtered = append(filtered, node)
+	}
+	return filtered, failedPredicateMap, nil
+}
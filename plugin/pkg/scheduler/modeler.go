@@ -0,0 +1,111 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+)
+
+// Modeler merges the scheduler's three views of the world - pods still waiting in the
+// queue, pods an informer has confirmed scheduled, and pods the scheduler has itself
+// assumed bound but not yet seen confirmed - into the single algorithm.PodLister that
+// predicates and priorities consult. Without it, a pod bound by another controller
+// isn't visible to predicates until the next full resync of the scheduled-pod informer,
+// which can let a second pod be placed on top of it.
+type Modeler interface {
+	// PodLister returns the merged, precedence-resolved view described above.
+	PodLister() algorithm.PodLister
+}
+
+// simpleModeler is the default Modeler.
+type simpleModeler struct {
+	mu            sync.Mutex
+	queuedPods    algorithm.PodLister
+	scheduledPods algorithm.PodLister
+	assumedPods   algorithm.PodLister
+}
+
+// NewSimpleModeler returns a Modeler that merges queuedPods, scheduledPods and
+// assumedPods with the following precedence: scheduled wins over assumed once the
+// informer backing scheduledPods confirms the bind, and an assumed pod is hidden
+// entirely if its key reappears in queuedPods (e.g. it was deleted and resubmitted).
+func NewSimpleModeler(queuedPods, scheduledPods, assumedPods algorithm.PodLister) Modeler {
+	return &simpleModeler{
+		queuedPods:    queuedPods,
+		scheduledPods: scheduledPods,
+		assumedPods:   assumedPods,
+	}
+}
+
+func (m *simpleModeler) PodLister() algorithm.PodLister {
+	return (*modelerPodLister)(m)
+}
+
+// modelerPodLister implements algorithm.PodLister by merging simpleModeler's three
+// sources on each List call.
+type modelerPodLister simpleModeler
+
+func (m *modelerPodLister) List(selector labels.Selector) ([]*api.Pod, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queued, err := m.queuedPods.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	scheduled, err := m.scheduledPods.List(selector)
+	if err != nil {
+		return nil, err
+	}
+	assumed, err := m.assumedPods.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	queuedKeys := make(map[string]bool, len(queued))
+	for _, pod := range queued {
+		queuedKeys[podModelKey(pod)] = true
+	}
+
+	merged := map[string]*api.Pod{}
+	for _, pod := range assumed {
+		if queuedKeys[podModelKey(pod)] {
+			continue
+		}
+		merged[podModelKey(pod)] = pod
+	}
+	for _, pod := range scheduled {
+		merged[podModelKey(pod)] = pod
+	}
+	for _, pod := range queued {
+		merged[podModelKey(pod)] = pod
+	}
+
+	result := make([]*api.Pod, 0, len(merged))
+	for _, pod := range merged {
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+func podModelKey(pod *api.Pod) string {
+	return pod.Namespace + "/" + pod.Name
+}
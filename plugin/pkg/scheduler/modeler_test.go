@@ -0,0 +1,160 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sort"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm/predicates"
+	schedulertesting "k8s.io/kubernetes/plugin/pkg/scheduler/testing"
+)
+
+func modelPod(name, nodeName string) *api.Pod {
+	return &api.Pod{
+		ObjectMeta: api.ObjectMeta{Name: name},
+		Spec:       api.PodSpec{NodeName: nodeName},
+	}
+}
+
+// TestModeler covers the queued/scheduled/assumed permutations: scheduled wins over
+// assumed once an informer confirms the bind, and an assumed pod is hidden if its key
+// reappears in the queue.
+func TestModeler(t *testing.T) {
+	table := []struct {
+		name      string
+		queued    []*api.Pod
+		scheduled []*api.Pod
+		assumed   []*api.Pod
+		want      []string // sorted pod names expected in the merged view
+	}{
+		{
+			name:   "queued only",
+			queued: []*api.Pod{modelPod("a", "")},
+			want:   []string{"a"},
+		},
+		{
+			name:      "scheduled only",
+			scheduled: []*api.Pod{modelPod("a", "machine1")},
+			want:      []string{"a"},
+		},
+		{
+			name:    "assumed only",
+			assumed: []*api.Pod{modelPod("a", "machine1")},
+			want:    []string{"a"},
+		},
+		{
+			name:      "scheduled wins over assumed once informer confirms",
+			assumed:   []*api.Pod{modelPod("a", "machine1")},
+			scheduled: []*api.Pod{modelPod("a", "machine1")},
+			want:      []string{"a"},
+		},
+		{
+			name:    "assumed hidden if pod reappears in queue",
+			assumed: []*api.Pod{modelPod("a", "machine1")},
+			queued:  []*api.Pod{modelPod("a", "")},
+			want:    []string{"a"},
+		},
+		{
+			name:      "disjoint pods from all three sources are all visible",
+			queued:    []*api.Pod{modelPod("a", "")},
+			scheduled: []*api.Pod{modelPod("b", "machine1")},
+			assumed:   []*api.Pod{modelPod("c", "machine2")},
+			want:      []string{"a", "b", "c"},
+		},
+	}
+
+	for _, item := range table {
+		t.Run(item.name, func(t *testing.T) {
+			modeler := NewSimpleModeler(
+				algorithm.FakePodLister(item.queued),
+				algorithm.FakePodLister(item.scheduled),
+				algorithm.FakePodLister(item.assumed),
+			)
+			pods, err := modeler.PodLister().List(labels.Everything())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got []string
+			for _, pod := range pods {
+				got = append(got, pod.Name)
+			}
+			sort.Strings(got)
+			if len(got) != len(item.want) {
+				t.Fatalf("got %v, want %v", got, item.want)
+			}
+			for i := range got {
+				if got[i] != item.want[i] {
+					t.Errorf("got %v, want %v", got, item.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestSchedulerSeesInformerConfirmedPodViaModeler is the scheduler-level complement to
+// TestModeler: it builds a Scheduler whose Algorithm is derived from Config.Modeler (via
+// New), with a pod occupying machine1 known only to the modeler's "scheduled" source -
+// as if another controller bound it and an informer confirmed it - while
+// SchedulerCache's own assumed-pod view knows nothing about it. scheduleOne must still
+// see the conflict, which it couldn't if Config.Modeler were dead wiring.
+func TestSchedulerSeesInformerConfirmedPodViaModeler(t *testing.T) {
+	firstPod := podWithPort("first", "machine1", 8080)
+	modeler := NewSimpleModeler(
+		algorithm.FakePodLister(nil),
+		algorithm.FakePodLister{firstPod},
+		&schedulertesting.FakeCache{},
+	)
+
+	secondPod := podWithPort("second", "", 8080)
+	var gotErr error
+	cfg := &Config{
+		SchedulerCache: &schedulertesting.FakeCache{},
+		NodeLister: algorithm.FakeNodeLister(
+			api.NodeList{Items: []api.Node{{ObjectMeta: api.ObjectMeta{Name: "machine1"}}}},
+		),
+		Modeler:    modeler,
+		Predicates: map[string]algorithm.FitPredicate{"PodFitsHostPorts": predicates.PodFitsHostPorts},
+		Binder: fakeBinder{func(b *api.Binding) error {
+			t.Fatalf("expected the port conflict to be caught before binding, got %v", b)
+			return nil
+		}},
+		PodConditionUpdater: fakePodConditionUpdater{},
+		Error:               func(p *api.Pod, err error) { gotErr = err },
+		NextPod:             func() *api.Pod { return secondPod },
+		Recorder:            &record.FakeRecorder{},
+	}
+
+	s := New(cfg)
+	if cfg.Algorithm == nil {
+		t.Fatalf("expected New to build Algorithm from Config.Modeler when Algorithm is nil")
+	}
+	s.scheduleOne()
+
+	fitErr, ok := gotErr.(*FitError)
+	if !ok {
+		t.Fatalf("expected a *FitError from the port conflict, got %v", gotErr)
+	}
+	if fitErr.FailedPredicates["machine1"] != "PodFitsHostPorts" {
+		t.Errorf("expected machine1 to fail PodFitsHostPorts, got %v", fitErr.FailedPredicates)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/wait"
+)
+
+// offerExpirerPeriod is how often the background expirer sweeps for perished offers.
+var offerExpirerPeriod = 1 * time.Second
+
+// Offer is a short-lived grant of a node's capacity that must be Claimed before the
+// scheduler may bind a pod to it. Offers mirror the Mesos resource-offer pattern: node
+// capacity is only reserved once someone claims the offer, and an unclaimed (or
+// unbound) offer perishes on its own after ExpiresAt so it never wedges capacity.
+type Offer struct {
+	ID        string
+	NodeName  string
+	Resources api.ResourceList
+	ExpiresAt time.Time
+}
+
+// expired reports whether the offer is no longer valid, whether or not it was claimed.
+func (o *Offer) expired(now time.Time) bool {
+	return !now.Before(o.ExpiresAt)
+}
+
+// OfferRegistry tracks perishable offers of node capacity. It decouples "a node looks
+// like a fit" from "this scheduler has reserved that capacity", so a multi-scheduler or
+// external resource-manager setup can veto a placement by simply not handing out (or by
+// revoking) an offer.
+type OfferRegistry interface {
+	// Add registers a new, unclaimed offer for nodeName that perishes after ttl.
+	Add(nodeName string, resources api.ResourceList, ttl time.Duration) *Offer
+
+	// Claim reserves the named offer for the caller. It fails if the offer doesn't
+	// exist, has already expired, or has already been claimed by someone else.
+	Claim(id string) (*Offer, error)
+
+	// Decline releases a claimed offer's capacity back to the node, e.g. after a
+	// failed bind. Declining an offer that was never claimed is a no-op.
+	Decline(id string) error
+
+	// List returns all offers that are currently unclaimed and unexpired.
+	List() []*Offer
+}
+
+// offerRecord is the registry's bookkeeping for a single offer.
+type offerRecord struct {
+	offer   *Offer
+	claimed bool
+}
+
+// offerRegistry is the in-memory OfferRegistry implementation used by the scheduler.
+type offerRegistry struct {
+	mu     sync.Mutex
+	offers map[string]*offerRecord
+	nextID int
+}
+
+// NewOfferRegistry returns an OfferRegistry whose background expirer runs until stop is
+// closed, sweeping away any offer - claimed or not - whose ExpiresAt has passed.
+func NewOfferRegistry(stop <-chan struct{}) OfferRegistry {
+	r := &offerRegistry{offers: map[string]*offerRecord{}}
+	go wait.Until(r.expireOffers, offerExpirerPeriod, stop)
+	return r
+}
+
+func (r *offerRegistry) Add(nodeName string, resources api.ResourceList, ttl time.Duration) *Offer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	offer := &Offer{
+		ID:        fmt.Sprintf("offer-%d", r.nextID),
+		NodeName:  nodeName,
+		Resources: resources,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	r.offers[offer.ID] = &offerRecord{offer: offer}
+	return offer
+}
+
+func (r *offerRegistry) Claim(id string) (*Offer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.offers[id]
+	if !ok {
+		return nil, fmt.Errorf("offer %v not found", id)
+	}
+	if rec.offer.expired(time.Now()) {
+		delete(r.offers, id)
+		return nil, fmt.Errorf("offer %v has expired", id)
+	}
+	if rec.claimed {
+		return nil, fmt.Errorf("offer %v is already claimed", id)
+	}
+	rec.claimed = true
+	return rec.offer, nil
+}
+
+func (r *offerRegistry) Decline(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec, ok := r.offers[id]
+	if !ok {
+		// Already gone, e.g. swept by the expirer; declining is then a no-op.
+		return nil
+	}
+	rec.claimed = false
+	return nil
+}
+
+func (r *offerRegistry) List() []*Offer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	offers := make([]*Offer, 0, len(r.offers))
+	for id, rec := range r.offers {
+		if rec.offer.expired(now) {
+			delete(r.offers, id)
+			continue
+		}
+		if rec.claimed {
+			continue
+		}
+		offers = append(offers, rec.offer)
+	}
+	return offers
+}
+
+// expireOffers removes every offer whose ExpiresAt has passed, whether or not it was
+// ever claimed, so a scheduler that claimed an offer but never bound it in time doesn't
+// wedge that node's capacity forever.
+func (r *offerRegistry) expireOffers() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for id, rec := range r.offers {
+		if rec.offer.expired(now) {
+			delete(r.offers, id)
+		}
+	}
+}
+
+// claimOfferFor finds and claims an unclaimed, unexpired offer for nodeName.
+func claimOfferFor(registry OfferRegistry, nodeName string) (*Offer, error) {
+	for _, candidate := range registry.List() {
+		if candidate.NodeName != nodeName {
+			continue
+		}
+		offer, err := registry.Claim(candidate.ID)
+		if err == nil {
+			return offer, nil
+		}
+	}
+	return nil, fmt.Errorf("no unclaimed offer available for node %v", nodeName)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	schedulertesting "k8s.io/kubernetes/plugin/pkg/scheduler/testing"
+)
+
+// TestOfferExpiresBeforeClaim is the offer-storage analogue of
+// TestSchedulerNoPhantomPodAfterExpire: an unclaimed offer must perish on its own so it
+// never wedges a node's capacity for longer than its ttl, and a claim made after it
+// perished fails while a fresh offer for the same node can still be claimed.
+func TestOfferExpiresBeforeClaim(t *testing.T) {
+	oldPeriod := offerExpirerPeriod
+	offerExpirerPeriod = 10 * time.Millisecond
+	defer func() { offerExpirerPeriod = oldPeriod }()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	registry := NewOfferRegistry(stop)
+
+	offer := registry.Add("machine1", api.ResourceList{}, 50*time.Millisecond)
+
+	waitExpireChan := make(chan struct{})
+	timeout := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-timeout:
+				return
+			default:
+			}
+			if len(registry.List()) == 0 {
+				close(waitExpireChan)
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+	select {
+	case <-waitExpireChan:
+	case <-time.After(wait.ForeverTestTimeout):
+		close(timeout)
+		t.Fatalf("timeout after %v", wait.ForeverTestTimeout)
+	}
+
+	if _, err := registry.Claim(offer.ID); err == nil {
+		t.Errorf("expected claiming an expired offer to fail")
+	}
+
+	fresh := registry.Add("machine1", api.ResourceList{}, 10*time.Minute)
+	if _, err := registry.Claim(fresh.ID); err != nil {
+		t.Errorf("expected to claim a fresh offer, got: %v", err)
+	}
+}
+
+// TestSchedulerDeclinesOfferOnBindFailure verifies that when Bind fails, scheduleOne
+// declines the offer it claimed so the node's capacity can be offered again.
+func TestSchedulerDeclinesOfferOnBindFailure(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	registry := NewOfferRegistry(stop)
+	offer := registry.Add("machine1", api.ResourceList{}, 10*time.Minute)
+
+	var forgotten *api.Pod
+	bindErr := errors.New("binder")
+	cfg := &Config{
+		SchedulerCache: &schedulertesting.FakeCache{
+			ForgetFunc: func(pod *api.Pod) { forgotten = pod },
+		},
+		NodeLister: algorithm.FakeNodeLister(
+			api.NodeList{Items: []api.Node{{ObjectMeta: api.ObjectMeta{Name: "machine1"}}}},
+		),
+		Algorithm:     mockScheduler{"machine1", nil},
+		OfferRegistry: registry,
+		Binder: fakeBinder{func(b *api.Binding) error {
+			return bindErr
+		}},
+		PodConditionUpdater: fakePodConditionUpdater{},
+		Error:               func(p *api.Pod, err error) {},
+		NextPod:             func() *api.Pod { return podWithID("foo", "") },
+		Recorder:            &record.FakeRecorder{},
+	}
+	s := New(cfg)
+	s.scheduleOne()
+
+	if forgotten == nil || forgotten.Name != "foo" {
+		t.Errorf("expected the assumed pod to be forgotten after a failed bind, got %v", forgotten)
+	}
+	// Declining puts the offer's capacity back in List(); it hasn't expired, so it
+	// must show up as available again rather than staying wedged as claimed.
+	found := false
+	for _, o := range registry.List() {
+		if o.ID == offer.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected offer %v to be declined back to available after the failed bind", offer.ID)
+	}
+}
+
+// TestOfferClaimContention verifies that when two scheduler instances race to claim the
+// same offer, exactly one succeeds.
+func TestOfferClaimContention(t *testing.T) {
+	stop := make(chan struct{})
+	defer close(stop)
+	registry := NewOfferRegistry(stop)
+	offer := registry.Add("machine1", api.ResourceList{}, 10*time.Minute)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	successes := 0
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := registry.Claim(offer.ID); err == nil {
+				mu.Lock()
+				successes++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if successes != 1 {
+		t.Errorf("expected exactly one scheduler to win the claim, got %v", successes)
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+)
+
+// PriorityClassAnnotationKey is the (alpha) annotation a pod can carry to opt into a
+// dedicated binding rate limiter bucket, analogous to how other alpha scheduling
+// features are threaded through pod annotations before graduating to API fields.
+const PriorityClassAnnotationKey = "scheduler.alpha.kubernetes.io/priority-class"
+
+// BindingRateLimiter controls how frequently the scheduler is allowed to bind pods to
+// nodes. Accept blocks the calling goroutine until the pod's binding may proceed, or
+// returns false if it gave up waiting.
+type BindingRateLimiter interface {
+	Accept(pod *api.Pod) bool
+}
+
+// RateLimiterOverride configures a distinct token bucket for a namespace or priority class.
+type RateLimiterOverride struct {
+	QPS   float32
+	Burst int
+}
+
+// BindingRateLimiterConfig configures the default, scheduler-wide token bucket used to
+// throttle binding, plus optional overrides keyed by namespace or priority class.
+type BindingRateLimiterConfig struct {
+	// QPS and Burst configure the default token bucket applied to pods that don't
+	// match a more specific override.
+	QPS   float32
+	Burst int
+
+	// NamespaceOverrides and PriorityClassOverrides, when set, give pods in the
+	// matching namespace or carrying the matching PriorityClassAnnotationKey their
+	// own token bucket instead of sharing the default one. Priority class overrides
+	// take precedence over namespace overrides.
+	NamespaceOverrides     map[string]RateLimiterOverride
+	PriorityClassOverrides map[string]RateLimiterOverride
+
+	// WaitTimeout bounds how long Accept blocks before giving up on a pod. Zero means
+	// wait indefinitely.
+	WaitTimeout time.Duration
+}
+
+// tokenBucketBindingRateLimiter is a BindingRateLimiter backed by per-bucket token
+// bucket rate limiters from pkg/util/flowcontrol.
+type tokenBucketBindingRateLimiter struct {
+	cfg BindingRateLimiterConfig
+
+	defaultLimiter flowcontrol.RateLimiter
+
+	mu                    sync.Mutex
+	namespaceLimiters     map[string]flowcontrol.RateLimiter
+	priorityClassLimiters map[string]flowcontrol.RateLimiter
+}
+
+// NewBindingRateLimiter returns a BindingRateLimiter that admits binds at cfg.QPS with
+// bursts up to cfg.Burst, honoring any per-namespace or per-priority-class overrides.
+func NewBindingRateLimiter(cfg BindingRateLimiterConfig) BindingRateLimiter {
+	rl := &tokenBucketBindingRateLimiter{
+		cfg:                   cfg,
+		defaultLimiter:        flowcontrol.NewTokenBucketRateLimiter(cfg.QPS, cfg.Burst),
+		namespaceLimiters:     make(map[string]flowcontrol.RateLimiter, len(cfg.NamespaceOverrides)),
+		priorityClassLimiters: make(map[string]flowcontrol.RateLimiter, len(cfg.PriorityClassOverrides)),
+	}
+	for ns, o := range cfg.NamespaceOverrides {
+		rl.namespaceLimiters[ns] = flowcontrol.NewTokenBucketRateLimiter(o.QPS, o.Burst)
+	}
+	for pc, o := range cfg.PriorityClassOverrides {
+		rl.priorityClassLimiters[pc] = flowcontrol.NewTokenBucketRateLimiter(o.QPS, o.Burst)
+	}
+	return rl
+}
+
+func (rl *tokenBucketBindingRateLimiter) limiterFor(pod *api.Pod) flowcontrol.RateLimiter {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if pc := pod.Annotations[PriorityClassAnnotationKey]; pc != "" {
+		if l, ok := rl.priorityClassLimiters[pc]; ok {
+			return l
+		}
+	}
+	if l, ok := rl.namespaceLimiters[pod.Namespace]; ok {
+		return l
+	}
+	return rl.defaultLimiter
+}
+
+// tokenPollInterval is how often Accept retries flowcontrol.RateLimiter.TryAccept while
+// waiting for a token within cfg.WaitTimeout.
+const tokenPollInterval = 10 * time.Millisecond
+
+// Accept blocks until the limiter selected for pod admits a token, or cfg.WaitTimeout
+// elapses, whichever comes first. It polls TryAccept rather than spawning a goroutine
+// around the blocking Accept, so a denial never leaves a waiter behind to silently
+// consume a token that frees up after we've already given up.
+func (rl *tokenBucketBindingRateLimiter) Accept(pod *api.Pod) bool {
+	limiter := rl.limiterFor(pod)
+	if rl.cfg.WaitTimeout <= 0 {
+		limiter.Accept()
+		return true
+	}
+
+	deadline := time.Now().Add(rl.cfg.WaitTimeout)
+	for {
+		if limiter.TryAccept() {
+			return true
+		}
+		if !time.Now().Before(deadline) {
+			return false
+		}
+		time.Sleep(tokenPollInterval)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	schedulertesting "k8s.io/kubernetes/plugin/pkg/scheduler/testing"
+)
+
+// countingRateLimiter allows the first `allow` calls to Accept and denies the rest,
+// simulating a token bucket that has exhausted its burst.
+type countingRateLimiter struct {
+	allow    int
+	accepted []string
+	denied   []string
+}
+
+func (c *countingRateLimiter) Accept(pod *api.Pod) bool {
+	if len(c.accepted) < c.allow {
+		c.accepted = append(c.accepted, pod.Name)
+		return true
+	}
+	c.denied = append(c.denied, pod.Name)
+	return false
+}
+
+// TestSchedulerRateLimitsBinding verifies that only as many pods as the limiter's burst
+// allows get bound, that every pod is still assumed while scheduleOne waits on the
+// limiter, and that a pod denied by the limiter is forgotten rather than left assumed.
+func TestSchedulerRateLimitsBinding(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(t.Logf).Stop()
+
+	limiter := &countingRateLimiter{allow: 2}
+	var assumedPods []*api.Pod
+	var forgottenPods []*api.Pod
+	var boundPods []*api.Binding
+
+	pods := []*api.Pod{podWithID("one", ""), podWithID("two", ""), podWithID("three", "")}
+	idx := 0
+
+	c := &Config{
+		SchedulerCache: &schedulertesting.FakeCache{
+			AssumeFunc: func(pod *api.Pod) { assumedPods = append(assumedPods, pod) },
+			ForgetFunc: func(pod *api.Pod) { forgottenPods = append(forgottenPods, pod) },
+		},
+		NodeLister: algorithm.FakeNodeLister(
+			api.NodeList{Items: []api.Node{{ObjectMeta: api.ObjectMeta{Name: "machine1"}}}},
+		),
+		Algorithm:   mockScheduler{"machine1", nil},
+		RateLimiter: limiter,
+		Binder: fakeBinder{func(b *api.Binding) error {
+			boundPods = append(boundPods, b)
+			return nil
+		}},
+		PodConditionUpdater: fakePodConditionUpdater{},
+		Error:               func(p *api.Pod, err error) {},
+		NextPod: func() *api.Pod {
+			p := pods[idx]
+			idx++
+			return p
+		},
+		Recorder: eventBroadcaster.NewRecorder(api.EventSource{Component: "scheduler"}),
+	}
+	s := New(c)
+
+	for range pods {
+		called := make(chan struct{})
+		events := eventBroadcaster.StartEventWatcher(func(e *api.Event) { close(called) })
+		s.scheduleOne()
+		<-called
+		events.Stop()
+	}
+
+	if e, a := 2, len(boundPods); e != a {
+		t.Errorf("expected %d bindings within the limiter's burst, got %d", e, a)
+	}
+	if e, a := 3, len(assumedPods); e != a {
+		t.Errorf("expected every pod to be assumed at least momentarily, got %d", a)
+	}
+	if e, a := 1, len(forgottenPods); e != a {
+		t.Fatalf("expected the rate-limited pod to be forgotten, got %d", a)
+	}
+	if e, a := "three", forgottenPods[0].Name; e != a {
+		t.Errorf("expected %q to be forgotten, got %q", e, a)
+	}
+}
+
+// TestBindingRateLimiterOverridePrecedence verifies limiterFor picks the priority-class
+// bucket over the namespace bucket, and the namespace bucket over the default one.
+func TestBindingRateLimiterOverridePrecedence(t *testing.T) {
+	rl := NewBindingRateLimiter(BindingRateLimiterConfig{
+		QPS:   1,
+		Burst: 1,
+		NamespaceOverrides: map[string]RateLimiterOverride{
+			"ns1": {QPS: 1, Burst: 1},
+		},
+		PriorityClassOverrides: map[string]RateLimiterOverride{
+			"high": {QPS: 1, Burst: 1},
+		},
+	}).(*tokenBucketBindingRateLimiter)
+
+	plain := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "other"}}
+	if got := rl.limiterFor(plain); got != rl.defaultLimiter {
+		t.Errorf("expected a pod in an unconfigured namespace to use the default limiter")
+	}
+
+	namespaced := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "ns1"}}
+	if got := rl.limiterFor(namespaced); got != rl.namespaceLimiters["ns1"] {
+		t.Errorf("expected a pod in ns1 to use its namespace override, not the default limiter")
+	}
+
+	prioritized := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace:   "ns1",
+		Annotations: map[string]string{PriorityClassAnnotationKey: "high"},
+	}}
+	if got := rl.limiterFor(prioritized); got != rl.priorityClassLimiters["high"] {
+		t.Errorf("expected a pod annotated with priority class high to use its override, not the namespace override")
+	}
+
+	unknownPriority := &api.Pod{ObjectMeta: api.ObjectMeta{
+		Namespace:   "ns1",
+		Annotations: map[string]string{PriorityClassAnnotationKey: "unconfigured"},
+	}}
+	if got := rl.limiterFor(unknownPriority); got != rl.namespaceLimiters["ns1"] {
+		t.Errorf("expected a pod with an unconfigured priority class to fall back to its namespace override")
+	}
+}
+
+// TestBindingRateLimiterWaitTimeoutDenies verifies that once a bucket's burst is
+// exhausted, Accept gives up and returns false after cfg.WaitTimeout rather than
+// blocking forever.
+func TestBindingRateLimiterWaitTimeoutDenies(t *testing.T) {
+	rl := NewBindingRateLimiter(BindingRateLimiterConfig{
+		QPS:         1,
+		Burst:       1,
+		WaitTimeout: 50 * time.Millisecond,
+	})
+	pod := &api.Pod{ObjectMeta: api.ObjectMeta{Namespace: "default"}}
+
+	if !rl.Accept(pod) {
+		t.Fatalf("expected the first request to consume the burst token and be accepted")
+	}
+	start := time.Now()
+	if rl.Accept(pod) {
+		t.Fatalf("expected the second request to be denied once the burst is exhausted")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("expected Accept to wait out cfg.WaitTimeout (50ms) before denying, only waited %v", elapsed)
+	}
+}
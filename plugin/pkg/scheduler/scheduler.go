@@ -0,0 +1,225 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/record"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/metrics"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+
+	"github.com/golang/glog"
+)
+
+// Binder knows how to write a binding.
+type Binder interface {
+	Bind(binding *api.Binding) error
+}
+
+// PodConditionUpdater updates the condition of a pod based on the passed in error.
+type PodConditionUpdater interface {
+	Update(pod *api.Pod, podCondition *api.PodCondition) error
+}
+
+// Config is an object that holds the actual scheduling logic, through the SchedulerAlgorithm. It receives a set of arguments
+// through the config, which allow it to wire together its various components.
+type Config struct {
+	// It is expected that changes made via SchedulerCache will be observed
+	// by NodeLister and Algorithm.
+	SchedulerCache schedulercache.Cache
+	NodeLister     algorithm.NodeLister
+	Algorithm      algorithm.ScheduleAlgorithm
+	Binder         Binder
+
+	// Modeler, if set and Algorithm is left nil, is used by New to build Algorithm as a
+	// genericScheduler backed by the merged queued/scheduled/assumed view from
+	// Modeler.PodLister() (see NewSimpleModeler) rather than whatever SchedulerCache
+	// alone knows about. Predicates and Priorities configure that genericScheduler; Extenders
+	// is optional. If Algorithm is already set, Modeler is ignored.
+	Modeler    Modeler
+	Predicates map[string]algorithm.FitPredicate
+	Priorities []algorithm.PriorityConfig
+	Extenders  []algorithm.SchedulerExtender
+
+	// RateLimiter throttles how often the scheduler is allowed to bind pods. A nil
+	// RateLimiter means binding is unthrottled. See NewBindingRateLimiter.
+	RateLimiter BindingRateLimiter
+
+	// OfferRegistry, if set, requires a node's capacity to be claimed as an Offer
+	// before the scheduler assumes a pod against it, and declines the offer again if
+	// binding doesn't go through. A nil OfferRegistry skips this two-phase dance
+	// entirely, assuming directly off of Algorithm's chosen node as before.
+	OfferRegistry OfferRegistry
+
+	// PodConditionUpdater is used only in case of scheduling errors. If we succeed
+	// with scheduling, PodScheduled condition will be updated in apiserver in /bind
+	// handler so that binding and setting PodCondition it is atomic.
+	PodConditionUpdater PodConditionUpdater
+
+	// NextPod should be a function that blocks until the next pod
+	// is available. We don't use a channel for this, because scheduling
+	// a pod may take some amount of time and we don't want pods to get
+	// stale while they sit in a channel.
+	NextPod func() *api.Pod
+
+	// Error is called if there is an error. It is passed the pod in
+	// question, and the error
+	Error func(*api.Pod, error)
+
+	// Backoff tracks per-pod retry backoff, e.g. for use by Error when re-enqueuing a
+	// pod that failed to schedule. A nil Backoff means nothing is reset on success.
+	Backoff *PodBackoff
+
+	// Recorder is the EventRecorder to use
+	Recorder record.EventRecorder
+}
+
+// Scheduler watches for new unscheduled pods. It attempts to find
+// nodes that they fit on and writes bindings back to the api server.
+type Scheduler struct {
+	config *Config
+}
+
+// New returns a new scheduler. If c.Algorithm is nil and c.Modeler is set, Algorithm is
+// built from the modeler's merged pod view so predicates see pods scheduled by other
+// controllers as soon as Modeler's scheduled-pod source observes them, rather than only
+// once SchedulerCache itself learns of them on the next resync.
+func New(c *Config) *Scheduler {
+	if c.Algorithm == nil && c.Modeler != nil {
+		c.Algorithm = NewGenericScheduler(c.Modeler.PodLister(), c.Predicates, c.Priorities, c.Extenders)
+	}
+	return &Scheduler{config: c}
+}
+
+// Run begins watching and scheduling. It starts a goroutine and returns immediately.
+func (s *Scheduler) Run(stopCh <-chan struct{}) {
+	go wait.Until(s.scheduleOne, 0, stopCh)
+}
+
+func (s *Scheduler) scheduleOne() {
+	pod := s.config.NextPod()
+
+	glog.V(3).Infof("Attempting to schedule: %+v", pod)
+	start := time.Now()
+	dest, err := s.config.Algorithm.Schedule(pod, s.config.NodeLister)
+	if err != nil {
+		glog.V(1).Infof("Failed to schedule: %+v", pod)
+		s.config.Recorder.Eventf(pod, "FailedScheduling", "%v", err)
+		s.config.Error(pod, err)
+		s.config.PodConditionUpdater.Update(pod, &api.PodCondition{
+			Type:   api.PodScheduled,
+			Status: api.ConditionFalse,
+			Reason: "Unschedulable",
+		})
+		return
+	}
+	metrics.SchedulingAlgorithmLatency.Observe(metrics.SinceInMicroseconds(start))
+
+	// If offers are in play, the chosen node's capacity isn't actually ours until we
+	// claim an offer for it; only then is it safe to assume the pod against the cache.
+	var claimedOffer *Offer
+	if s.config.OfferRegistry != nil {
+		offer, err := claimOfferFor(s.config.OfferRegistry, dest)
+		if err != nil {
+			glog.V(1).Infof("Failed to claim an offer for pod %v/%v on %v: %v", pod.Namespace, pod.Name, dest, err)
+			s.config.Recorder.Eventf(pod, "FailedScheduling", "%v", err)
+			s.config.Error(pod, err)
+			s.config.PodConditionUpdater.Update(pod, &api.PodCondition{
+				Type:   api.PodScheduled,
+				Status: api.ConditionFalse,
+				Reason: "Unschedulable",
+			})
+			return
+		}
+		claimedOffer = offer
+	}
+
+	// We assume the pod here since it would be racy to assume it in the binding event
+	// handler. The pod stays assumed even if binding is delayed by the rate limiter
+	// below; it is only forgotten if binding is ultimately denied or fails.
+	assumed := *pod
+	assumed.Spec.NodeName = dest
+	if err := s.config.SchedulerCache.AssumePod(&assumed); err != nil {
+		glog.Errorf("scheduler cache AssumePod failed: %v", err)
+		s.config.Error(pod, err)
+		return
+	}
+
+	if s.config.RateLimiter != nil {
+		waitStart := time.Now()
+		allowed := s.config.RateLimiter.Accept(pod)
+		metrics.BindingRateLimiterWait.Observe(metrics.SinceInMicroseconds(waitStart))
+		if !allowed {
+			if err := s.config.SchedulerCache.ForgetPod(&assumed); err != nil {
+				glog.Errorf("scheduler cache ForgetPod failed: %v", err)
+			}
+			if claimedOffer != nil {
+				s.declineOffer(claimedOffer)
+			}
+			err := fmt.Errorf("binding for pod %v/%v throttled for too long", pod.Namespace, pod.Name)
+			s.config.Recorder.Eventf(pod, "RateLimited", "%v", err)
+			s.config.Error(pod, err)
+			return
+		}
+	}
+
+	b := &api.Binding{
+		ObjectMeta: api.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+		Target: api.ObjectReference{
+			Kind: "Node",
+			Name: dest,
+		},
+	}
+
+	bindingStart := time.Now()
+	err = s.config.Binder.Bind(b)
+	if err != nil {
+		glog.V(1).Infof("Failed to bind pod: %+v", err)
+		if err := s.config.SchedulerCache.ForgetPod(&assumed); err != nil {
+			glog.Errorf("scheduler cache ForgetPod failed: %v", err)
+		}
+		if claimedOffer != nil {
+			s.declineOffer(claimedOffer)
+		}
+		s.config.Recorder.Eventf(pod, "FailedScheduling", "Binding rejected: %v", err)
+		s.config.Error(pod, err)
+		s.config.PodConditionUpdater.Update(pod, &api.PodCondition{
+			Type:   api.PodScheduled,
+			Status: api.ConditionFalse,
+			Reason: "BindingRejected",
+		})
+		return
+	}
+	metrics.BindingLatency.Observe(metrics.SinceInMicroseconds(bindingStart))
+	if s.config.Backoff != nil {
+		s.config.Backoff.Clear(pod.UID)
+	}
+	s.config.Recorder.Eventf(pod, "Scheduled", "Successfully assigned %v to %v", pod.Name, dest)
+}
+
+// declineOffer releases a claimed offer back to its node, logging rather than failing
+// the caller if the decline itself errors - the offer will still perish on its own.
+func (s *Scheduler) declineOffer(offer *Offer) {
+	if err := s.config.OfferRegistry.Decline(offer.ID); err != nil {
+		glog.Errorf("Error declining offer %v: %v", offer.ID, err)
+	}
+}
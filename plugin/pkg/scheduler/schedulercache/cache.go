@@ -0,0 +1,164 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedulercache
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/golang/glog"
+)
+
+var (
+	cleanAssumedPeriod = 1 * time.Second
+)
+
+// podInfo tracks the pod and, for an assumed pod, the deadline after which it expires.
+type podInfo struct {
+	pod      *api.Pod
+	assumed  bool
+	deadline *time.Time
+}
+
+// schedulerCache implements Cache using a single lock guarding a map of pods, keyed
+// by the pod's namespace/name, plus a TTL for assumed-but-unconfirmed pods.
+type schedulerCache struct {
+	ttl  time.Duration
+	stop <-chan struct{}
+
+	mu        sync.Mutex
+	podStates map[string]*podInfo
+}
+
+// New returns a Cache implementation that expires assumed pods after ttl if they are
+// never confirmed via AddPod.
+func New(ttl time.Duration, stop <-chan struct{}) Cache {
+	cache := &schedulerCache{
+		ttl:       ttl,
+		stop:      stop,
+		podStates: make(map[string]*podInfo),
+	}
+	go wait.Until(cache.cleanupExpiredAssumedPods, cleanAssumedPeriod, stop)
+	return cache
+}
+
+func getPodKey(pod *api.Pod) (string, error) {
+	return cache.MetaNamespaceKeyFunc(pod)
+}
+
+func (cache *schedulerCache) AssumePod(pod *api.Pod) error {
+	key, err := getPodKey(pod)
+	if err != nil {
+		return err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if _, ok := cache.podStates[key]; ok {
+		return fmt.Errorf("pod %v state was already in cache", key)
+	}
+	dl := time.Now().Add(cache.ttl)
+	cache.podStates[key] = &podInfo{pod: pod, assumed: true, deadline: &dl}
+	return nil
+}
+
+func (cache *schedulerCache) ForgetPod(pod *api.Pod) error {
+	key, err := getPodKey(pod)
+	if err != nil {
+		return err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	ps, ok := cache.podStates[key]
+	if !ok || !ps.assumed {
+		return fmt.Errorf("pod %v wasn't assumed so cannot be forgotten", key)
+	}
+	delete(cache.podStates, key)
+	return nil
+}
+
+func (cache *schedulerCache) AddPod(pod *api.Pod) error {
+	key, err := getPodKey(pod)
+	if err != nil {
+		return err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.podStates[key] = &podInfo{pod: pod, assumed: false}
+	return nil
+}
+
+func (cache *schedulerCache) UpdatePod(oldPod, newPod *api.Pod) error {
+	key, err := getPodKey(oldPod)
+	if err != nil {
+		return err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.podStates, key)
+	newKey, err := getPodKey(newPod)
+	if err != nil {
+		return err
+	}
+	cache.podStates[newKey] = &podInfo{pod: newPod, assumed: false}
+	return nil
+}
+
+func (cache *schedulerCache) RemovePod(pod *api.Pod) error {
+	key, err := getPodKey(pod)
+	if err != nil {
+		return err
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	delete(cache.podStates, key)
+	return nil
+}
+
+func (cache *schedulerCache) List(selector labels.Selector) ([]*api.Pod, error) {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	pods := make([]*api.Pod, 0, len(cache.podStates))
+	for _, ps := range cache.podStates {
+		if selector.Matches(labels.Set(ps.pod.Labels)) {
+			pods = append(pods, ps.pod)
+		}
+	}
+	return pods, nil
+}
+
+// cleanupExpiredAssumedPods removes assumed pods whose deadline has passed without
+// a confirming AddPod call.
+func (cache *schedulerCache) cleanupExpiredAssumedPods() {
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	now := time.Now()
+	for key, ps := range cache.podStates {
+		if !ps.assumed {
+			continue
+		}
+		if now.After(*ps.deadline) {
+			glog.V(3).Infof("Removing expired assumed pod %v from scheduler cache", key)
+			delete(cache.podStates, key)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedulercache provides a cache of pods and nodes the scheduler uses to
+// make scheduling decisions without round-tripping through the apiserver.
+package schedulercache
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+// Cache collects pods' information and lists the cached pods; it keeps no node-level aggregates.
+// It's intended for generic scheduler to do efficient lookup.
+// Cache's operations are pod centric. It does incremental updates based on pod events.
+// Pod events are sent via network. We don't have guaranteed delivery of all events:
+// We use Reflector to list and watch from remote. Reflector might be slow and do a relist,
+// which would lead to missing events.
+//
+// State Machine of a pod's events in scheduler's cache:
+//
+//	+-------------------------------------------+  +----+
+//	|                            Add            |  |    |
+//	|                                            |  |    | Update
+//	+      Assume                Add             v  v    |
+//
+// Initial +--------> Assumed +------------+---> Added <--+
+//   - |       +
+//     |                |       |
+//     |           Add  |       | Remove
+//     |                |       |
+//     |                +       v
+//     +-------------> Expired and/or Deleted
+//     Expire
+//
+// Note that an assumed pod can expire, because if we haven't received Add event notifying us
+// for a while, there might be some problems and we shouldn't keep the pod in cache anymore.
+//
+// Note that "Initial", "Expired" and "Deleted" pods do not actually exist in cache.
+// Based on existing use cases, we are making the following assumptions:
+//   - No pod would be assumed twice
+//   - A pod could be added without going through scheduler, in which case we will see Add but not Assume event.
+//   - If a pod wasn't added, it wouldn't be removed or updated.
+//   - Both "Expired" and "Deleted" are valid end states. In case of some problems, e.g. network issue,
+//     a pod might have changed its state (e.g. added and deleted) without delivering notification to the cache.
+type Cache interface {
+	// AssumePod assumes a pod scheduled and records it in the cache.
+	// The implementation also decides the policy to expire pod before being confirmed (receiving Add event).
+	// After expiration, the pod is removed from the cache.
+	AssumePod(pod *api.Pod) error
+
+	// AddPod either confirms a pod if it's assumed, or adds it back if it's expired.
+	AddPod(pod *api.Pod) error
+
+	// UpdatePod removes oldPod's information and adds newPod's information.
+	UpdatePod(oldPod, newPod *api.Pod) error
+
+	// RemovePod removes a pod from the cache.
+	RemovePod(pod *api.Pod) error
+
+	// ForgetPod removes an assumed pod from cache.
+	ForgetPod(pod *api.Pod) error
+
+	// List lists all cached pods that match the given selector.
+	List(selector labels.Selector) ([]*api.Pod, error)
+}
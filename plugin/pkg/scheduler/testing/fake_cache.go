@@ -0,0 +1,51 @@
+/*
+Copyright 2014 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/labels"
+)
+
+// FakeCache is used for testing the scheduler's interaction with schedulercache.Cache
+// without requiring a real cache implementation.
+type FakeCache struct {
+	AssumeFunc func(*api.Pod)
+	ForgetFunc func(*api.Pod)
+}
+
+func (f *FakeCache) AssumePod(pod *api.Pod) error {
+	if f.AssumeFunc != nil {
+		f.AssumeFunc(pod)
+	}
+	return nil
+}
+
+func (f *FakeCache) ForgetPod(pod *api.Pod) error {
+	if f.ForgetFunc != nil {
+		f.ForgetFunc(pod)
+	}
+	return nil
+}
+
+func (f *FakeCache) AddPod(pod *api.Pod) error { return nil }
+
+func (f *FakeCache) UpdatePod(oldPod, newPod *api.Pod) error { return nil }
+
+func (f *FakeCache) RemovePod(pod *api.Pod) error { return nil }
+
+func (f *FakeCache) List(selector labels.Selector) ([]*api.Pod, error) { return nil, nil }